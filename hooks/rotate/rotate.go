@@ -0,0 +1,266 @@
+// Package rotate provides a logrus.Hook that writes formatted log entries to
+// a file and rotates it based on size, age, or a daily schedule.
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/JarvistFth/logrus"
+)
+
+// RotatingFileHook writes formatted log entries to Filename and rolls it
+// over once it grows past MaxSizeBytes or crosses the RotateAt time of day,
+// pruning backups past MaxBackups/MaxAge as it goes. A single hook can be
+// filtered to a subset of levels via LogLevels, so e.g. errors can be routed
+// to their own rotated file while everything else stays on stdout.
+type RotatingFileHook struct {
+	// Filename is the path log entries are written to. Required.
+	Filename string
+
+	// Formatter renders each Entry before it's written to Filename.
+	// Defaults to &logrus.JWFormatter{DisableColors: true} when nil.
+	Formatter logrus.Formatter
+
+	// LogLevels restricts which levels this hook fires for. Defaults to
+	// logrus.AllLevels.
+	LogLevels []logrus.Level
+
+	// MaxSizeBytes rotates the file once writing the next entry would
+	// exceed this size. Zero disables size-based rotation.
+	MaxSizeBytes int64
+
+	// MaxAge prunes rotated backups older than this. Zero keeps backups
+	// indefinitely.
+	MaxAge time.Duration
+
+	// MaxBackups caps the number of rotated backups kept on disk. Zero
+	// keeps them all.
+	MaxBackups int
+
+	// RotateAt, when non-zero, forces a rotation the first time an entry
+	// is logged past this time-of-day on a later day than the one the
+	// current file was opened on. No RotateAt rotation happens on the day
+	// the current file was opened.
+	RotateAt time.Duration
+
+	// Compress gzips rotated backups asynchronously.
+	Compress bool
+
+	mu             sync.Mutex
+	file           *os.File
+	size           int64
+	openedDay      int
+	sighupOnce     sync.Once
+	defaultFmtOnce sync.Once
+	defaultFmt     logrus.Formatter
+}
+
+var _ logrus.Hook = (*RotatingFileHook)(nil)
+
+// Levels implements logrus.Hook.
+func (h *RotatingFileHook) Levels() []logrus.Level {
+	if h.LogLevels != nil {
+		return h.LogLevels
+	}
+	return logrus.AllLevels
+}
+
+// formatter returns h.Formatter, or a lazily-built default with colors
+// forced off: this hook writes to a file, not a terminal, so it must not
+// colorize based on the main logger's unrelated Out destination.
+func (h *RotatingFileHook) formatter() logrus.Formatter {
+	if h.Formatter != nil {
+		return h.Formatter
+	}
+	h.defaultFmtOnce.Do(func() {
+		h.defaultFmt = &logrus.JWFormatter{DisableColors: true}
+	})
+	return h.defaultFmt
+}
+
+// Fire implements logrus.Hook. It is safe for concurrent use.
+func (h *RotatingFileHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter().Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.watchSIGHUP()
+
+	if h.file == nil {
+		if err := h.open(entry.Time); err != nil {
+			return err
+		}
+	}
+
+	if h.shouldRotate(entry.Time, int64(len(line))) {
+		if err := h.rotate(entry.Time); err != nil {
+			return err
+		}
+	}
+
+	n, err := h.file.Write(line)
+	h.size += int64(n)
+	return err
+}
+
+func (h *RotatingFileHook) open(now time.Time) error {
+	if dir := filepath.Dir(h.Filename); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(h.Filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	h.file = f
+	h.size = info.Size()
+	h.openedDay = now.YearDay()
+	return nil
+}
+
+func (h *RotatingFileHook) shouldRotate(now time.Time, nextWriteSize int64) bool {
+	if h.MaxSizeBytes > 0 && h.size+nextWriteSize > h.MaxSizeBytes {
+		return true
+	}
+	if h.RotateAt > 0 && now.YearDay() != h.openedDay {
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if now.Sub(midnight) >= h.RotateAt {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *RotatingFileHook) rotate(now time.Time) error {
+	if err := h.file.Close(); err != nil {
+		return err
+	}
+	h.file = nil
+
+	backup := fmt.Sprintf("%s.%s", h.Filename, now.Format("20060102-150405.000"))
+	if err := os.Rename(h.Filename, backup); err != nil {
+		return err
+	}
+
+	if h.Compress {
+		go compress(backup)
+	}
+
+	// Run synchronously, under h.mu (held by the caller, Fire): MaxBackups
+	// must be enforced by the time Fire returns, not at some later,
+	// unobservable point once a background goroutine gets scheduled.
+	h.prune()
+
+	return h.open(now)
+}
+
+func compress(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// prune removes rotated backups of Filename that exceed MaxBackups or are
+// older than MaxAge. It runs synchronously as part of rotate(), under h.mu,
+// so MaxBackups/MaxAge are already enforced by the time Fire returns.
+func (h *RotatingFileHook) prune() {
+	if h.MaxBackups <= 0 && h.MaxAge <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(h.Filename)
+	base := filepath.Base(h.Filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var backups []os.FileInfo
+	for _, e := range entries {
+		name := e.Name()
+		if name == base || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, info)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+
+	now := time.Now()
+	for i, info := range backups {
+		tooOld := h.MaxAge > 0 && now.Sub(info.ModTime()) > h.MaxAge
+		tooMany := h.MaxBackups > 0 && i >= h.MaxBackups
+		if tooOld || tooMany {
+			os.Remove(filepath.Join(dir, info.Name()))
+		}
+	}
+}
+
+// watchSIGHUP arranges for the hook to close and reopen Filename on SIGHUP,
+// so external tools like logrotate that rename the file out from under the
+// hook are picked up without losing log lines. Called with h.mu held.
+func (h *RotatingFileHook) watchSIGHUP() {
+	h.sighupOnce.Do(func() {
+		sighup := make(chan os.Signal, 1)
+		signal.Notify(sighup, syscall.SIGHUP)
+		go func() {
+			for range sighup {
+				h.mu.Lock()
+				if h.file != nil {
+					h.file.Close()
+					h.file = nil
+				}
+				h.mu.Unlock()
+			}
+		}()
+	})
+}
@@ -0,0 +1,63 @@
+package rotate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/JarvistFth/logrus"
+)
+
+func TestRotatingFileHookRotatesOnSize(t *testing.T) {
+	// logger.Info fires hooks synchronously, and RotatingFileHook.Fire
+	// prunes backups synchronously too, so the directory is in its final
+	// state as soon as the loop below returns - no extra synchronization
+	// needed.
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+
+	hook := &RotatingFileHook{
+		Filename:     logFile,
+		MaxSizeBytes: 64,
+		MaxBackups:   2,
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stdout)
+	logger.AddHook(hook)
+
+	for i := 0; i < 20; i++ {
+		logger.Info("this is a log line long enough to force rotation")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+
+	var backups int
+	for _, e := range entries {
+		if e.Name() != "app.log" {
+			backups++
+		}
+	}
+
+	if backups == 0 {
+		t.Fatalf("expected at least one rotated backup, found none")
+	}
+	if backups > hook.MaxBackups {
+		t.Fatalf("expected at most %d backups, found %d", hook.MaxBackups, backups)
+	}
+}
+
+func TestRotatingFileHookLevels(t *testing.T) {
+	hook := &RotatingFileHook{
+		Filename:  filepath.Join(t.TempDir(), "errors.log"),
+		LogLevels: []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel},
+	}
+
+	levels := hook.Levels()
+	if len(levels) != 3 {
+		t.Fatalf("Levels() = %v, want 3 entries", levels)
+	}
+}
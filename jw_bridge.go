@@ -0,0 +1,118 @@
+package logrus
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+// HeaderMap maps a leading stdlib log line header (e.g. "debug:", "[DEBUG]")
+// to the logrus Level it should be dispatched at.
+type HeaderMap map[string]Level
+
+// DefaultHeaderMap is the set of headers recognised out of the box when a
+// StdLogBridge doesn't override them, modelled after the header conventions
+// of github.com/comoyo/colog.
+var DefaultHeaderMap = HeaderMap{
+	"trace:":   TraceLevel,
+	"debug:":   DebugLevel,
+	"info:":    InfoLevel,
+	"warn:":    WarnLevel,
+	"warning:": WarnLevel,
+	"error:":   ErrorLevel,
+	"fatal:":   FatalLevel,
+	"panic:":   PanicLevel,
+	"[TRACE]":  TraceLevel,
+	"[DEBUG]":  DebugLevel,
+	"[INFO]":   InfoLevel,
+	"[WARN]":   WarnLevel,
+	"[ERROR]":  ErrorLevel,
+	"[FATAL]":  FatalLevel,
+	"[PANIC]":  PanicLevel,
+	"T:":       TraceLevel,
+	"D:":       DebugLevel,
+	"I:":       InfoLevel,
+	"W:":       WarnLevel,
+	"E:":       ErrorLevel,
+	"F:":       FatalLevel,
+	"P:":       PanicLevel,
+}
+
+// StdLogBridge is an io.Writer that parses the leading level header off each
+// line written by the standard library's log package and redispatches it as
+// a logrus Entry, so third-party code that only speaks log.Printf still gets
+// JWFormatter's level coloring and caller info.
+type StdLogBridge struct {
+	// Logger receives the parsed entries. Defaults to the package-level
+	// standard logger when nil.
+	Logger *Logger
+
+	// HeaderMap customizes or extends the recognised line headers. It is
+	// consulted before DefaultHeaderMap, so entries here take priority.
+	HeaderMap HeaderMap
+
+	// DefaultLevel is used for lines that carry no recognisable header.
+	DefaultLevel Level
+
+	// MinLevel filters out entries below this level before they reach
+	// the formatter. A nil MinLevel (the default) applies no filtering at
+	// all: the zero Level value is PanicLevel, the most severe level, so
+	// a non-pointer field would silently drop everything but panics for
+	// the common zero-value construction StdLogBridge{}.
+	MinLevel *Level
+}
+
+func (b *StdLogBridge) logger() *Logger {
+	if b.Logger != nil {
+		return b.Logger
+	}
+	return std
+}
+
+// Write implements io.Writer. It never returns an error for a malformed or
+// unrecognised line; such lines are logged at DefaultLevel instead.
+func (b *StdLogBridge) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+
+	// Level ordering runs most-to-least severe (PanicLevel=0 ... TraceLevel=6),
+	// so a line is below MinLevel severity when its Level is numerically greater.
+	level, message := b.parseHeader(line)
+	if b.MinLevel != nil && level > *b.MinLevel {
+		return len(p), nil
+	}
+
+	b.logger().Log(level, message)
+	return len(p), nil
+}
+
+func (b *StdLogBridge) parseHeader(line string) (Level, string) {
+	for header, level := range b.HeaderMap {
+		if strings.HasPrefix(line, header) {
+			return level, strings.TrimSpace(strings.TrimPrefix(line, header))
+		}
+	}
+	for header, level := range DefaultHeaderMap {
+		if strings.HasPrefix(line, header) {
+			return level, strings.TrimSpace(strings.TrimPrefix(line, header))
+		}
+	}
+	return b.DefaultLevel, line
+}
+
+// Register installs b as the destination of the standard library's log
+// package and disables its own timestamp/prefix so that headers like
+// "debug:" or "[DEBUG]" can be parsed unambiguously from the start of each
+// line.
+func Register(b *StdLogBridge) {
+	log.SetOutput(b)
+	log.SetFlags(0)
+	log.SetPrefix("")
+}
+
+// Unregister restores the standard library's log package to its default
+// output and flags.
+func Unregister() {
+	log.SetOutput(os.Stderr)
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("")
+}
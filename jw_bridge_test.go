@@ -0,0 +1,98 @@
+package logrus
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStdLogBridgeParseHeader(t *testing.T) {
+	b := &StdLogBridge{DefaultLevel: InfoLevel}
+
+	tests := []struct {
+		line      string
+		wantLevel Level
+		wantMsg   string
+	}{
+		{"debug: starting worker", DebugLevel, "starting worker"},
+		{"[ERROR] connection refused", ErrorLevel, "connection refused"},
+		{"E: disk full", ErrorLevel, "disk full"},
+		{"no header here", InfoLevel, "no header here"},
+	}
+
+	for _, tt := range tests {
+		level, msg := b.parseHeader(tt.line)
+		if level != tt.wantLevel || msg != tt.wantMsg {
+			t.Errorf("parseHeader(%q) = (%v, %q), want (%v, %q)", tt.line, level, msg, tt.wantLevel, tt.wantMsg)
+		}
+	}
+}
+
+func levelPtr(l Level) *Level {
+	return &l
+}
+
+func TestStdLogBridgeMinLevelDropsLessSevere(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(TraceLevel)
+
+	b := &StdLogBridge{Logger: logger, DefaultLevel: InfoLevel, MinLevel: levelPtr(ErrorLevel)}
+
+	if _, err := b.Write([]byte("info: just noise\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected info-level line below MinLevel to be dropped, got %q", buf.String())
+	}
+
+	if _, err := b.Write([]byte("error: disk full\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "disk full") {
+		t.Fatalf("expected error-level line to be logged, got %q", buf.String())
+	}
+}
+
+func TestStdLogBridgeMinLevelKeepsMoreSevere(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(TraceLevel)
+
+	b := &StdLogBridge{Logger: logger, DefaultLevel: InfoLevel, MinLevel: levelPtr(WarnLevel)}
+
+	if _, err := b.Write([]byte("[ERROR] boom\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Fatalf("expected a more-severe-than-MinLevel line to be logged, got %q", buf.String())
+	}
+}
+
+func TestStdLogBridgeZeroValueMinLevelFiltersNothing(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New()
+	logger.SetOutput(&buf)
+	logger.SetLevel(TraceLevel)
+
+	// StdLogBridge{} is the construction MinLevel's doc comment describes;
+	// it must not silently drop everything but Panic-level lines.
+	b := &StdLogBridge{Logger: logger}
+
+	if _, err := b.Write([]byte("error: disk full\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "disk full") {
+		t.Fatalf("expected a zero-value StdLogBridge to pass error-level lines through, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if _, err := b.Write([]byte("trace: very chatty\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "very chatty") {
+		t.Fatalf("expected a zero-value StdLogBridge to pass trace-level lines through too, got %q", buf.String())
+	}
+}
@@ -0,0 +1,98 @@
+package logrus
+
+import "github.com/mgutz/ansi"
+
+// ColorScheme lets callers restyle any segment of a colored JWFormatter line
+// independently instead of forking the formatter. Each field is parsed in
+// mgutz/ansi syntax, e.g. "green+b", "black+h", or an xterm-256 color number
+// such as "226".
+type ColorScheme struct {
+	InfoLevelStyle  string
+	WarnLevelStyle  string
+	ErrorLevelStyle string
+	FatalLevelStyle string
+	PanicLevelStyle string
+	DebugLevelStyle string
+	TraceLevelStyle string
+	TimestampStyle  string
+	CallerStyle     string
+	MessageStyle    string
+	FieldKeyStyle   string
+	FieldValueStyle string
+}
+
+type compiledColorScheme struct {
+	InfoLevelColor  func(string) string
+	WarnLevelColor  func(string) string
+	ErrorLevelColor func(string) string
+	FatalLevelColor func(string) string
+	PanicLevelColor func(string) string
+	DebugLevelColor func(string) string
+	TraceLevelColor func(string) string
+	TimestampColor  func(string) string
+	CallerColor     func(string) string
+	MessageColor    func(string) string
+	FieldKeyColor   func(string) string
+	FieldValueColor func(string) string
+}
+
+// DefaultColorScheme colors the whole line (timestamp, caller, message) and
+// the field keys in the level's shade: blue for info, debug and trace,
+// yellow for warn, red for error, fatal and panic. Field values are left
+// uncolored. Leaving Timestamp/Caller/Message/FieldKeyStyle
+// empty opts into that level-shaded default; set any of them to style the
+// corresponding segment independently of the level.
+var DefaultColorScheme = &ColorScheme{
+	InfoLevelStyle:  "blue",
+	WarnLevelStyle:  "yellow",
+	ErrorLevelStyle: "red",
+	FatalLevelStyle: "red",
+	PanicLevelStyle: "red",
+	DebugLevelStyle: "blue",
+	TraceLevelStyle: "blue",
+}
+
+// colorFunc compiles style, or returns nil if style is empty so callers can
+// fall back to another color (typically the level's).
+func colorFunc(style string) func(string) string {
+	if style == "" {
+		return nil
+	}
+	return ansi.ColorFunc(style)
+}
+
+func compileColorScheme(s *ColorScheme) *compiledColorScheme {
+	return &compiledColorScheme{
+		InfoLevelColor:  ansi.ColorFunc(s.InfoLevelStyle),
+		WarnLevelColor:  ansi.ColorFunc(s.WarnLevelStyle),
+		ErrorLevelColor: ansi.ColorFunc(s.ErrorLevelStyle),
+		FatalLevelColor: ansi.ColorFunc(s.FatalLevelStyle),
+		PanicLevelColor: ansi.ColorFunc(s.PanicLevelStyle),
+		DebugLevelColor: ansi.ColorFunc(s.DebugLevelStyle),
+		TraceLevelColor: ansi.ColorFunc(s.TraceLevelStyle),
+		TimestampColor:  colorFunc(s.TimestampStyle),
+		CallerColor:     colorFunc(s.CallerStyle),
+		MessageColor:    colorFunc(s.MessageStyle),
+		FieldKeyColor:   colorFunc(s.FieldKeyStyle),
+		FieldValueColor: colorFunc(s.FieldValueStyle),
+	}
+}
+
+func (c *compiledColorScheme) levelColor(level Level) func(string) string {
+	switch level {
+	case TraceLevel:
+		return c.TraceLevelColor
+	case DebugLevel:
+		return c.DebugLevelColor
+	case WarnLevel:
+		return c.WarnLevelColor
+	case ErrorLevel:
+		return c.ErrorLevelColor
+	case FatalLevel:
+		return c.FatalLevelColor
+	case PanicLevel:
+		return c.PanicLevelColor
+	default:
+		return c.InfoLevelColor
+	}
+}
@@ -0,0 +1,118 @@
+package logrus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompileColorSchemeLevelStyles(t *testing.T) {
+	scheme := compileColorScheme(DefaultColorScheme)
+
+	tests := []struct {
+		name  string
+		color func(string) string
+	}{
+		{"info", scheme.InfoLevelColor},
+		{"warn", scheme.WarnLevelColor},
+		{"error", scheme.ErrorLevelColor},
+		{"fatal", scheme.FatalLevelColor},
+		{"panic", scheme.PanicLevelColor},
+		{"debug", scheme.DebugLevelColor},
+		{"trace", scheme.TraceLevelColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.color == nil {
+				t.Fatal("expected a compiled color func, got nil")
+			}
+			got := tt.color("text")
+			if !strings.Contains(got, "text") {
+				t.Errorf("colored output = %q, want it to contain the original text", got)
+			}
+			if !strings.Contains(got, "\x1b[") {
+				t.Errorf("colored output = %q, want an ANSI escape sequence", got)
+			}
+		})
+	}
+}
+
+func TestCompileColorSchemeUnsetSegmentStylesAreNil(t *testing.T) {
+	scheme := compileColorScheme(DefaultColorScheme)
+
+	if scheme.TimestampColor != nil {
+		t.Error("expected TimestampColor to be nil when TimestampStyle is unset")
+	}
+	if scheme.CallerColor != nil {
+		t.Error("expected CallerColor to be nil when CallerStyle is unset")
+	}
+	if scheme.MessageColor != nil {
+		t.Error("expected MessageColor to be nil when MessageStyle is unset")
+	}
+	if scheme.FieldKeyColor != nil {
+		t.Error("expected FieldKeyColor to be nil when FieldKeyStyle is unset")
+	}
+	if scheme.FieldValueColor != nil {
+		t.Error("expected FieldValueColor to be nil when FieldValueStyle is unset")
+	}
+}
+
+func TestCompileColorSchemeExplicitSegmentStyle(t *testing.T) {
+	scheme := compileColorScheme(&ColorScheme{
+		InfoLevelStyle: "blue",
+		CallerStyle:    "green+b",
+	})
+
+	if scheme.CallerColor == nil {
+		t.Fatal("expected CallerColor to be compiled when CallerStyle is set")
+	}
+	if got := scheme.CallerColor("caller.go:1"); !strings.Contains(got, "caller.go:1") {
+		t.Errorf("CallerColor output = %q, want it to contain the original text", got)
+	}
+}
+
+func TestCompiledColorSchemeLevelColorSelectsMatchingField(t *testing.T) {
+	// Every level gets a distinct style so a wrongly selected field shows up.
+	scheme := compileColorScheme(&ColorScheme{
+		InfoLevelStyle:  "green",
+		WarnLevelStyle:  "yellow",
+		ErrorLevelStyle: "red",
+		FatalLevelStyle: "magenta",
+		PanicLevelStyle: "white",
+		DebugLevelStyle: "blue",
+		TraceLevelStyle: "cyan",
+	})
+
+	tests := []struct {
+		level Level
+		want  func(string) string
+	}{
+		{TraceLevel, scheme.TraceLevelColor},
+		{DebugLevel, scheme.DebugLevelColor},
+		{InfoLevel, scheme.InfoLevelColor},
+		{WarnLevel, scheme.WarnLevelColor},
+		{ErrorLevel, scheme.ErrorLevelColor},
+		{FatalLevel, scheme.FatalLevelColor},
+		{PanicLevel, scheme.PanicLevelColor},
+	}
+
+	for _, tt := range tests {
+		got := scheme.levelColor(tt.level)
+		if got("x") != tt.want("x") {
+			t.Errorf("levelColor(%v) produced a different func than the expected level style", tt.level)
+		}
+	}
+}
+
+func TestOrDefaultFallsBackWhenColorUnset(t *testing.T) {
+	fallback := func(s string) string { return "fallback:" + s }
+
+	if got := orDefault(nil, fallback)("x"); got != "fallback:x" {
+		t.Errorf("orDefault(nil, fallback)(%q) = %q, want %q", "x", got, "fallback:x")
+	}
+
+	explicit := func(s string) string { return "explicit:" + s }
+	if got := orDefault(explicit, fallback)("x"); got != "explicit:x" {
+		t.Errorf("orDefault(explicit, fallback)(%q) = %q, want %q", "x", got, "explicit:x")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -33,6 +35,35 @@ type JWFormatter struct {
 	// PadLevelText is a superset of the DisableLevelTruncation option
 	PadLevelText bool
 
+	// The fields are sorted by default for a consistent output. For applications
+	// that log extremely frequently and don't use the JSON formatter this may not
+	// be desired.
+	DisableSorting bool
+
+	// The keys sorting function, when uninitialized it uses sort.Strings.
+	SortingFunc func([]string)
+
+	// Force quoting of all values
+	ForceQuote bool
+
+	// DisableQuote disables quoting for all values.
+	// DisableQuote will have a lower priority than ForceQuote.
+	// If both of them are set to true, quote will be forced on all values.
+	DisableQuote bool
+
+	// FieldMap allows users to customize the names of keys for default fields.
+	// As an example:
+	// formatter := &JWFormatter{
+	//     FieldMap: FieldMap{
+	//          FieldKeyTime:  "@timestamp",
+	//          FieldKeyLevel: "@level",
+	//          FieldKeyMsg:   "@message"}}
+	FieldMap FieldMap
+
+	// ColorScheme lets callers restyle any segment of a colored line
+	// without forking the formatter. Defaults to DefaultColorScheme.
+	ColorScheme *ColorScheme
+
 	// Whether the logger's out is to a terminal
 	isTerminal bool
 
@@ -46,6 +77,16 @@ type JWFormatter struct {
 
 	// The max length of the level text, generated dynamically on init
 	levelTextMaxLength int
+
+	// The ColorScheme compiled into per-segment closures, generated once on init.
+	compiledColorScheme *compiledColorScheme
+}
+
+// SetColorScheme installs scheme as the ColorScheme. Pass nil to fall back to
+// DefaultColorScheme. The scheme is compiled once, on the formatter's first
+// Format call; calling SetColorScheme after that does not change the output.
+func (f *JWFormatter) SetColorScheme(scheme *ColorScheme) {
+	f.ColorScheme = scheme
 }
 
 func (f *JWFormatter) init(entry *Entry) {
@@ -59,6 +100,12 @@ func (f *JWFormatter) init(entry *Entry) {
 			f.levelTextMaxLength = levelTextLength
 		}
 	}
+
+	scheme := f.ColorScheme
+	if scheme == nil {
+		scheme = DefaultColorScheme
+	}
+	f.compiledColorScheme = compileColorScheme(scheme)
 }
 
 func (f *JWFormatter) Format(entry *Entry) ([]byte, error) {
@@ -105,19 +152,6 @@ func checkStdOut(w io.Writer) bool {
 }
 
 func (f *JWFormatter) formatOutput(b *bytes.Buffer, entry *Entry, withColor bool) {
-	var levelColor int
-	switch entry.Level {
-	case DebugLevel, TraceLevel:
-		levelColor = blue
-	case WarnLevel:
-		levelColor = yellow
-	case ErrorLevel, FatalLevel, PanicLevel:
-		levelColor = red
-	case InfoLevel:
-		//levelColor = green
-	default:
-		levelColor = blue
-	}
 	timestampFormat := f.TimestampFormat
 	if timestampFormat == "" {
 		timestampFormat = JWDefaultTimeFormat
@@ -141,9 +175,109 @@ func (f *JWFormatter) formatOutput(b *bytes.Buffer, entry *Entry, withColor bool
 		caller = fmt.Sprintf("%s [%s]", fileVal, funcVal)
 	}
 	if withColor {
-		fmt.Fprintf(b, "\x1b[%dm[%s] %s %s %s", levelColor, entry.Level, entry.Time.Format(timestampFormat), caller, entry.Message)
+		scheme := f.compiledColorScheme
+		levelColor := scheme.levelColor(entry.Level)
+		timestampColor := orDefault(scheme.TimestampColor, levelColor)
+		callerColor := orDefault(scheme.CallerColor, levelColor)
+		messageColor := orDefault(scheme.MessageColor, levelColor)
+		fmt.Fprintf(b, "[%s] %s %s %s",
+			levelColor(entry.Level.String()),
+			timestampColor(entry.Time.Format(timestampFormat)),
+			callerColor(caller),
+			messageColor(entry.Message),
+		)
 	} else {
 		fmt.Fprintf(b, "[%s] %s %s %s", entry.Level, entry.Time.Format(timestampFormat), caller, entry.Message)
 	}
 
+	f.writeFields(b, entry, withColor)
+}
+
+// orDefault returns color, or fallback if color is nil. Used so an unset
+// ColorScheme style falls back to the level's shade.
+func orDefault(color, fallback func(string) string) func(string) string {
+	if color != nil {
+		return color
+	}
+	return fallback
+}
+
+// writeFields appends the entry's structured Data as `key=value` pairs after
+// the message, resolving any clashes with the reserved time/level/msg/func/file
+// keys through FieldMap first.
+func (f *JWFormatter) writeFields(b *bytes.Buffer, entry *Entry, withColor bool) {
+	data := make(Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	prefixFieldClashes(data, f.FieldMap, entry.HasCaller())
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+
+	if !f.DisableSorting {
+		if f.SortingFunc != nil {
+			f.SortingFunc(keys)
+		} else {
+			sort.Strings(keys)
+		}
+	}
+
+	for _, key := range keys {
+		b.WriteByte(' ')
+		f.appendKeyValue(b, key, data[key], entry.Level, withColor)
+	}
+}
+
+func (f *JWFormatter) appendKeyValue(b *bytes.Buffer, key string, value interface{}, level Level, withColor bool) {
+	if withColor {
+		scheme := f.compiledColorScheme
+		keyColor := orDefault(scheme.FieldKeyColor, scheme.levelColor(level))
+		b.WriteString(keyColor(key))
+		b.WriteByte('=')
+		if scheme.FieldValueColor != nil {
+			b.WriteString(scheme.FieldValueColor(f.valueString(value)))
+		} else {
+			b.WriteString(f.valueString(value))
+		}
+		return
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(f.valueString(value))
+}
+
+func (f *JWFormatter) valueString(value interface{}) string {
+	stringVal, ok := value.(string)
+	if !ok {
+		stringVal = fmt.Sprint(value)
+	}
+
+	if !f.needsQuoting(stringVal) {
+		return stringVal
+	}
+	return strconv.Quote(stringVal)
+}
+
+func (f *JWFormatter) needsQuoting(text string) bool {
+	if f.ForceQuote {
+		return true
+	}
+	if f.DisableQuote {
+		return false
+	}
+	if len(text) == 0 {
+		return false
+	}
+	for _, ch := range text {
+		if !((ch >= 'a' && ch <= 'z') ||
+			(ch >= 'A' && ch <= 'Z') ||
+			(ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '.' || ch == '_' || ch == '/' || ch == '@' || ch == '^' || ch == '+') {
+			return true
+		}
+	}
+	return false
 }
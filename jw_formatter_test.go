@@ -1,7 +1,11 @@
 package logrus
 
 import (
+	"bytes"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestJWFormatting(t *testing.T) {
@@ -15,3 +19,118 @@ func TestJWFormatting(t *testing.T) {
 	Warnf("warning!!")
 	Error("error!!!")
 }
+
+func TestJWFormatterNeedsQuoting(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *JWFormatter
+		text string
+		want bool
+	}{
+		{"simple word", &JWFormatter{}, "value", false},
+		{"empty string", &JWFormatter{}, "", false},
+		{"contains space", &JWFormatter{}, "hello world", true},
+		{"contains quote", &JWFormatter{}, `say "hi"`, true},
+		{"contains newline", &JWFormatter{}, "line1\nline2", true},
+		{"force quote overrides safe text", &JWFormatter{ForceQuote: true}, "value", true},
+		{"disable quote overrides unsafe text", &JWFormatter{DisableQuote: true}, "hello world", false},
+		{"force quote wins over disable quote", &JWFormatter{ForceQuote: true, DisableQuote: true}, "value", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.needsQuoting(tt.text); got != tt.want {
+				t.Errorf("needsQuoting(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWFormatterValueString(t *testing.T) {
+	f := &JWFormatter{}
+
+	if got := f.valueString("plain"); got != "plain" {
+		t.Errorf("valueString(%q) = %q, want unquoted", "plain", got)
+	}
+
+	if got, want := f.valueString("two words"), `"two words"`; got != want {
+		t.Errorf("valueString(%q) = %q, want %q", "two words", got, want)
+	}
+
+	if got, want := f.valueString(42), "42"; got != want {
+		t.Errorf("valueString(42) = %q, want %q", got, want)
+	}
+}
+
+func TestJWFormatterWriteFieldsSorting(t *testing.T) {
+	entry := &Entry{
+		Level: InfoLevel,
+		Time:  time.Now(),
+		Data:  Fields{"zebra": 1, "apple": 2, "mango": 3},
+	}
+
+	f := &JWFormatter{}
+	b := &bytes.Buffer{}
+	f.writeFields(b, entry, false)
+
+	out := b.String()
+	if !(strings.Index(out, "apple") < strings.Index(out, "mango") &&
+		strings.Index(out, "mango") < strings.Index(out, "zebra")) {
+		t.Fatalf("expected alphabetically sorted fields, got %q", out)
+	}
+}
+
+func TestJWFormatterSortingFunc(t *testing.T) {
+	entry := &Entry{
+		Level: InfoLevel,
+		Time:  time.Now(),
+		Data:  Fields{"b": 1, "a": 2, "c": 3},
+	}
+
+	f := &JWFormatter{
+		SortingFunc: func(keys []string) {
+			sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+		},
+	}
+	b := &bytes.Buffer{}
+	f.writeFields(b, entry, false)
+
+	out := b.String()
+	if !(strings.Index(out, "c=") < strings.Index(out, "b=") && strings.Index(out, "b=") < strings.Index(out, "a=")) {
+		t.Fatalf("expected SortingFunc to reverse-sort keys, got %q", out)
+	}
+}
+
+func TestJWFormatterFieldMapRenamesReservedKey(t *testing.T) {
+	entry := &Entry{
+		Level: InfoLevel,
+		Time:  time.Now(),
+		Data:  Fields{"msg": "collides with the reserved message key"},
+	}
+
+	f := &JWFormatter{}
+	b := &bytes.Buffer{}
+	f.writeFields(b, entry, false)
+
+	out := b.String()
+	if !strings.Contains(out, "fields.msg=") {
+		t.Fatalf("expected colliding 'msg' field to be renamed to fields.msg, got %q", out)
+	}
+}
+
+func TestJWFormatterFieldMapCustomReservedKey(t *testing.T) {
+	entry := &Entry{
+		Level: InfoLevel,
+		Time:  time.Now(),
+		Data:  Fields{"message": "collides once FieldKeyMsg is remapped"},
+	}
+
+	f := &JWFormatter{FieldMap: FieldMap{FieldKeyMsg: "message"}}
+	b := &bytes.Buffer{}
+	f.writeFields(b, entry, false)
+
+	out := b.String()
+	if !strings.Contains(out, "fields.message=") {
+		t.Fatalf("expected 'message' field to be renamed once FieldKeyMsg maps to it, got %q", out)
+	}
+}